@@ -0,0 +1,47 @@
+package nlib
+
+import (
+	"github.com/rs/zerolog"
+	"github.com/sirupsen/logrus"
+)
+
+// LogrusFormatter is a logrus.Formatter, in the style of Diun's
+// LogrusFormatter, that forwards each entry into an underlying NLog instead
+// of rendering it to bytes. Since Format returns no bytes, logrus itself
+// writes nothing to the logrus.Logger's Out.
+type LogrusFormatter struct {
+	N *NLog
+}
+
+// Format forwards entry to f.N, preserving its level and structured fields,
+// and returns no bytes since the entry has already been delivered to N.
+func (f *LogrusFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	event := logrusLevelToEvent(f.N, entry.Level)
+	for k, v := range entry.Data {
+		event = event.Interface(k, v)
+	}
+	event.Msg(entry.Message)
+	return nil, nil
+}
+
+func logrusLevelToEvent(n *NLog, level logrus.Level) *zerolog.Event {
+	switch level {
+	case logrus.TraceLevel:
+		return n.Trace()
+	case logrus.DebugLevel:
+		return n.Debug()
+	case logrus.InfoLevel:
+		return n.Info()
+	case logrus.WarnLevel:
+		return n.Warn()
+	case logrus.ErrorLevel:
+		return n.Error()
+	case logrus.FatalLevel, logrus.PanicLevel:
+		// Use Error rather than n.Fatal()/n.Panic(): triggering zerolog's
+		// os.Exit/panic from inside a Formatter call would kill the process
+		// underneath logrus instead of letting it handle the entry itself.
+		return n.Error()
+	default:
+		return n.Info()
+	}
+}
@@ -0,0 +1,49 @@
+package nlib
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestLogrusFormatterForwardsLevelAndFields(t *testing.T) {
+	var buf bytes.Buffer
+	n := NewLog(NLogConfig{Writers: []io.Writer{&buf}})
+	buf.Reset()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	logger.SetFormatter(&LogrusFormatter{N: n})
+
+	logger.WithField("component", "auth").Warn("token expiring")
+
+	out := buf.String()
+	if !strings.Contains(out, `"level":"warn"`) {
+		t.Fatalf("want warn level forwarded, got %q", out)
+	}
+	if !strings.Contains(out, "token expiring") {
+		t.Fatalf("want message forwarded, got %q", out)
+	}
+	if !strings.Contains(out, `"component":"auth"`) {
+		t.Fatalf("want structured field forwarded, got %q", out)
+	}
+}
+
+func TestLogrusFormatterMapsFatalAndPanicToError(t *testing.T) {
+	var buf bytes.Buffer
+	n := NewLog(NLogConfig{Writers: []io.Writer{&buf}})
+	buf.Reset()
+
+	f := &LogrusFormatter{N: n}
+	entry := &logrus.Entry{Level: logrus.FatalLevel, Message: "would have exited"}
+	if _, err := f.Format(entry); err != nil {
+		t.Fatalf("Format returned error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), `"level":"error"`) {
+		t.Fatalf("want fatal mapped to zerolog error rather than triggering os.Exit, got %q", buf.String())
+	}
+}
@@ -0,0 +1,135 @@
+package nlib
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+// stdLogWriter adapts a stdlib log.Logger's writes into INFO-level zerolog
+// events, trimming the trailing newline log.Logger always appends.
+type stdLogWriter struct {
+	n *NLog
+}
+
+func (w *stdLogWriter) Write(p []byte) (int, error) {
+	w.n.Info().Msg(strings.TrimRight(string(p), "\n"))
+	return len(p), nil
+}
+
+// Std returns a stdlib *log.Logger whose writes are parsed into INFO-level
+// events on n, so third-party code that only knows the standard log package
+// can be captured alongside the rest of an application's logging.
+func (n *NLog) Std() *log.Logger {
+	return log.New(&stdLogWriter{n: n}, "", 0)
+}
+
+// slogHandler adapts slog.Handler to an underlying NLog, mapping slog levels
+// to zerolog levels and slog attrs/groups to zerolog fields.
+type slogHandler struct {
+	n      *NLog
+	groups []string
+	attrs  []boundSlogAttr
+}
+
+// boundSlogAttr pairs an attr added by WithAttrs with the group prefix that
+// was in effect at the time, so a later WithGroup call on the handler
+// doesn't retroactively re-qualify attrs that were already bound to an
+// outer scope.
+type boundSlogAttr struct {
+	groups []string
+	attr   slog.Attr
+}
+
+// Slog returns a log/slog.Logger backed by n, mapping slog attrs, groups,
+// and levels onto zerolog fields and levels so libraries written against
+// log/slog can be captured alongside the rest of an application's logging.
+func (n *NLog) Slog() *slog.Logger {
+	return slog.New(&slogHandler{n: n})
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.n.Logger.GetLevel() <= slogLevelToZerolog(level)
+}
+
+func (h *slogHandler) Handle(_ context.Context, r slog.Record) error {
+	event := h.n.Logger.WithLevel(slogLevelToZerolog(r.Level))
+	for _, ba := range h.attrs {
+		event = appendSlogAttr(event, ba.groups, ba.attr)
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		event = appendSlogAttr(event, h.groups, a)
+		return true
+	})
+	event.Msg(r.Message)
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	merged := make([]boundSlogAttr, 0, len(h.attrs)+len(attrs))
+	merged = append(merged, h.attrs...)
+	for _, a := range attrs {
+		merged = append(merged, boundSlogAttr{groups: h.groups, attr: a})
+	}
+	return &slogHandler{n: h.n, groups: h.groups, attrs: merged}
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	groups := make([]string, 0, len(h.groups)+1)
+	groups = append(groups, h.groups...)
+	groups = append(groups, name)
+	return &slogHandler{n: h.n, groups: groups, attrs: h.attrs}
+}
+
+func slogLevelToZerolog(level slog.Level) zerolog.Level {
+	switch {
+	case level < slog.LevelDebug:
+		return zerolog.TraceLevel
+	case level < slog.LevelInfo:
+		return zerolog.DebugLevel
+	case level < slog.LevelWarn:
+		return zerolog.InfoLevel
+	case level < slog.LevelError:
+		return zerolog.WarnLevel
+	default:
+		return zerolog.ErrorLevel
+	}
+}
+
+func appendSlogAttr(event *zerolog.Event, groups []string, a slog.Attr) *zerolog.Event {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return event
+	}
+	key := a.Key
+	if len(groups) > 0 {
+		key = strings.Join(groups, ".") + "." + key
+	}
+	switch a.Value.Kind() {
+	case slog.KindString:
+		return event.Str(key, a.Value.String())
+	case slog.KindInt64:
+		return event.Int64(key, a.Value.Int64())
+	case slog.KindUint64:
+		return event.Uint64(key, a.Value.Uint64())
+	case slog.KindFloat64:
+		return event.Float64(key, a.Value.Float64())
+	case slog.KindBool:
+		return event.Bool(key, a.Value.Bool())
+	case slog.KindDuration:
+		return event.Dur(key, a.Value.Duration())
+	case slog.KindTime:
+		return event.Time(key, a.Value.Time())
+	case slog.KindGroup:
+		nested := append(append([]string{}, groups...), a.Key)
+		for _, ga := range a.Value.Group() {
+			event = appendSlogAttr(event, nested, ga)
+		}
+		return event
+	default:
+		return event.Interface(key, a.Value.Any())
+	}
+}
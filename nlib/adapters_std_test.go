@@ -0,0 +1,74 @@
+package nlib
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestStdLogWriterTrimsTrailingNewline(t *testing.T) {
+	var buf bytes.Buffer
+	n := NewLog(NLogConfig{Writers: []io.Writer{&buf}})
+	buf.Reset()
+
+	n.Std().Print("hello from stdlib log")
+
+	if !strings.Contains(buf.String(), "hello from stdlib log") {
+		t.Fatalf("want the message forwarded to n, got %q", buf.String())
+	}
+	if strings.Count(buf.String(), "\n") != 1 {
+		t.Fatalf("want exactly one trailing newline (zerolog's own), got %q", buf.String())
+	}
+}
+
+func TestSlogHandlerBindsAttrsToGroupAtWithAttrsTime(t *testing.T) {
+	var buf bytes.Buffer
+	n := NewLog(NLogConfig{Writers: []io.Writer{&buf}})
+	buf.Reset()
+
+	handler := &slogHandler{n: n}
+	bound := handler.WithGroup("g1").WithAttrs([]slog.Attr{slog.String("x", "v1")}).WithGroup("g2")
+
+	logger := slog.New(bound)
+	logger.Info("hi")
+
+	out := buf.String()
+	if !strings.Contains(out, `"g1.x":"v1"`) {
+		t.Fatalf("want attr qualified with the group in effect when WithAttrs was called (g1.x), got %q", out)
+	}
+	if strings.Contains(out, "g2.x") || strings.Contains(out, "g1.g2.x") {
+		t.Fatalf("want WithGroup(\"g2\") not to retroactively re-qualify an already-bound attr, got %q", out)
+	}
+}
+
+func TestSlogHandlerQualifiesRecordAttrsWithCurrentGroup(t *testing.T) {
+	var buf bytes.Buffer
+	n := NewLog(NLogConfig{Writers: []io.Writer{&buf}})
+	buf.Reset()
+
+	logger := n.Slog().WithGroup("g1")
+	logger.Info("hi", slog.String("y", "v2"))
+
+	if !strings.Contains(buf.String(), `"g1.y":"v2"`) {
+		t.Fatalf("want record attrs qualified with the group active at call time, got %q", buf.String())
+	}
+}
+
+func TestSlogHandlerEnabledRespectsNLogLevel(t *testing.T) {
+	var buf bytes.Buffer
+	n := NewLog(NLogConfig{Writers: []io.Writer{&buf}})
+	n.SetLevel(zerolog.WarnLevel)
+
+	h := &slogHandler{n: n}
+	if h.Enabled(context.Background(), slog.LevelInfo) {
+		t.Error("want Info disabled once NLog's level is raised to Warn")
+	}
+	if !h.Enabled(context.Background(), slog.LevelWarn) {
+		t.Error("want Warn enabled at Warn level")
+	}
+}
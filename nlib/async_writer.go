@@ -0,0 +1,179 @@
+package nlib
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"sync"
+	"time"
+)
+
+// asyncWriter buffers writes and flushes them to an underlying io.Writer from
+// a single background goroutine, the way Sync Gateway's logCollationWorker
+// does: entries pile up in a channel until either bufferSize bytes have
+// accumulated or flushInterval has elapsed since the first unflushed entry,
+// whichever comes first. This trades a small amount of latency for far fewer
+// write syscalls under load.
+type asyncWriter struct {
+	out           io.Writer
+	bufferSize    int
+	flushInterval time.Duration
+
+	entries chan []byte
+	flushC  chan chan struct{}
+	closeC  chan struct{}
+	wg      sync.WaitGroup
+
+	writeWG sync.WaitGroup // tracks Write calls in flight so Close can wait for them before closing closeC
+
+	// closeMu guards closed, serializing it against writeWG.Add so a Write
+	// can never call Add concurrently with or after Close's writeWG.Wait
+	// starts: sync.WaitGroup forbids that ordering outright (the race
+	// detector flags it even when it would happen to be harmless), so an
+	// atomic flag alone isn't enough to close this race.
+	closeMu sync.RWMutex
+	closed  bool
+}
+
+func newAsyncWriter(out io.Writer, bufferSize int, flushInterval time.Duration) *asyncWriter {
+	if bufferSize <= 0 {
+		bufferSize = 256 * 1024
+	}
+	if flushInterval <= 0 {
+		flushInterval = time.Second
+	}
+	w := &asyncWriter{
+		out:           out,
+		bufferSize:    bufferSize,
+		flushInterval: flushInterval,
+		entries:       make(chan []byte, 1024),
+		flushC:        make(chan chan struct{}),
+		closeC:        make(chan struct{}),
+	}
+	w.wg.Add(1)
+	go w.run()
+	return w
+}
+
+// Write copies p, since zerolog may reuse its buffer after Write returns,
+// and hands it to the collation worker. It never blocks on the underlying
+// writer. Once Close has started, Write short-circuits instead of joining
+// writeWG, so a caller that keeps writing through shutdown cannot hold
+// Close's wait open forever; writeWG only ever waits for writes that were
+// already in flight the moment Close was called.
+func (w *asyncWriter) Write(p []byte) (int, error) {
+	w.closeMu.RLock()
+	if w.closed {
+		w.closeMu.RUnlock()
+		return len(p), nil
+	}
+	w.writeWG.Add(1)
+	w.closeMu.RUnlock()
+	defer w.writeWG.Done()
+
+	buf := make([]byte, len(p))
+	copy(buf, p)
+	select {
+	case w.entries <- buf:
+	case <-w.closeC:
+	}
+	return len(p), nil
+}
+
+// Flush blocks until every entry buffered so far has been written to the
+// underlying writer.
+func (w *asyncWriter) Flush() {
+	done := make(chan struct{})
+	select {
+	case w.flushC <- done:
+		<-done
+	case <-w.closeC:
+	}
+}
+
+// Close stops new Write calls from joining writeWG, waits for any Write that
+// was already in flight to finish handing its entry to the collation
+// worker, then drains whatever is queued, stops the worker, and waits for it
+// to exit before returning, guaranteeing no buffered line is lost without
+// risking an unbounded wait on a caller that keeps writing through shutdown.
+func (w *asyncWriter) Close() error {
+	w.closeMu.Lock()
+	w.closed = true
+	w.closeMu.Unlock()
+
+	w.writeWG.Wait()
+	close(w.closeC)
+	w.wg.Wait()
+	return nil
+}
+
+func (w *asyncWriter) run() {
+	defer w.wg.Done()
+
+	var buf bytes.Buffer
+	// An idle logger should not wake up on its own: start with an
+	// effectively-infinite timer and reset it to flushInterval the moment
+	// the first entry of a batch is buffered.
+	timer := time.NewTimer(math.MaxInt64)
+	timerSet := false
+	stopTimer := func() {
+		if !timer.Stop() {
+			<-timer.C
+		}
+	}
+
+	flush := func() {
+		if buf.Len() == 0 {
+			return
+		}
+		_, _ = w.out.Write(buf.Bytes())
+		buf.Reset()
+	}
+
+	for {
+		select {
+		case entry := <-w.entries:
+			buf.Write(entry)
+			if !timerSet {
+				stopTimer()
+				timer.Reset(w.flushInterval)
+				timerSet = true
+			}
+			if buf.Len() >= w.bufferSize {
+				stopTimer()
+				timer.Reset(math.MaxInt64)
+				timerSet = false
+				flush()
+			}
+		case <-timer.C:
+			timerSet = false
+			flush()
+			timer.Reset(math.MaxInt64)
+		case done := <-w.flushC:
+			// Pick up entries already handed off by Write but not yet
+			// received, so Flush covers every Write that has returned.
+			for drained := false; !drained; {
+				select {
+				case entry := <-w.entries:
+					buf.Write(entry)
+				default:
+					drained = true
+				}
+			}
+			flush()
+			close(done)
+		case <-w.closeC:
+			stopTimer()
+			// Drain whatever is already queued before exiting.
+			for {
+				select {
+				case entry := <-w.entries:
+					buf.Write(entry)
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package nlib
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+)
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestAsyncWriterFlushesOnBufferSize(t *testing.T) {
+	out := &syncBuffer{}
+	w := newAsyncWriter(out, len("hello"), time.Hour)
+	defer w.Close()
+
+	if _, err := w.Write([]byte("hello")); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for out.String() != "hello" && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if got := out.String(); got != "hello" {
+		t.Fatalf("want size-triggered flush to write %q, got %q", "hello", got)
+	}
+}
+
+func TestAsyncWriterFlushesOnTimer(t *testing.T) {
+	out := &syncBuffer{}
+	w := newAsyncWriter(out, 256*1024, 20*time.Millisecond)
+	defer w.Close()
+
+	if _, err := w.Write([]byte("hello")); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	if got := out.String(); got != "" {
+		t.Fatalf("want nothing flushed before the timer fires, got %q", got)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for out.String() != "hello" && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if got := out.String(); got != "hello" {
+		t.Fatalf("want timer-triggered flush to write %q, got %q", "hello", got)
+	}
+}
+
+func TestAsyncWriterCloseDoesNotLoseBufferedEntries(t *testing.T) {
+	out := &syncBuffer{}
+	w := newAsyncWriter(out, 256*1024, time.Hour)
+
+	if _, err := w.Write([]byte("hello")); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	if got := out.String(); got != "hello" {
+		t.Fatalf("want Close to flush buffered entries, got %q", got)
+	}
+}
+
+func TestAsyncWriterCloseFlushesWriteFromAnotherGoroutine(t *testing.T) {
+	out := &syncBuffer{}
+	w := newAsyncWriter(out, 256*1024, time.Hour)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if _, err := w.Write([]byte("hello")); err != nil {
+			t.Errorf("Write returned error: %v", err)
+		}
+	}()
+	wg.Wait()
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	if got := out.String(); got != "hello" {
+		t.Fatalf("want Close to flush the write made on another goroutine, got %q", got)
+	}
+}
+
+func TestAsyncWriterCloseDoesNotHangUnderConcurrentWriters(t *testing.T) {
+	out := &syncBuffer{}
+	w := newAsyncWriter(out, 256*1024, time.Hour)
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					_, _ = w.Write([]byte("x"))
+				}
+			}
+		}()
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		_ = w.Close()
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+	case <-time.After(3 * time.Second):
+		t.Fatal("Close hung with writers still hammering Write")
+	}
+	close(stop)
+	wg.Wait()
+}
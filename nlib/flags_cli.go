@@ -0,0 +1,45 @@
+package nlib
+
+import "github.com/urfave/cli/v2"
+
+// CLILogFlags returns the --log.* urfave/cli flag family, mirroring
+// RegisterLogFlags for applications built on urfave/cli instead of the
+// stdlib flag package.
+func CLILogFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{Name: FlagLogRotate, Value: true, Usage: "Enable log file rotation"},
+		&cli.StringFlag{Name: FlagLogFile, Value: "app.log", Usage: "Log file name"},
+		&cli.StringFlag{Name: FlagLogDir, Value: "logs", Usage: "Directory to store log files in"},
+		&cli.IntFlag{Name: FlagLogMaxSize, Value: 100, Usage: "Maximum size in MB of a log file before it gets rotated"},
+		&cli.IntFlag{Name: FlagLogMaxBackups, Value: 10, Usage: "Maximum number of rotated log files to retain"},
+		&cli.IntFlag{Name: FlagLogMaxAge, Value: 28, Usage: "Maximum number of days to retain a rotated log file"},
+		&cli.BoolFlag{Name: FlagLogCompress, Usage: "Compress rotated log files with gzip"},
+		&cli.StringFlag{Name: FlagLogLevel, Value: "info", Usage: "Log level: trace, debug, info, warn, error, fatal, panic"},
+		&cli.BoolFlag{Name: FlagLogJSON, Usage: "Emit structured JSON log lines instead of console-formatted ones"},
+		&cli.BoolFlag{Name: FlagLogNoColor, Usage: "Disable ANSI colors in console output"},
+		&cli.BoolFlag{Name: FlagLogConsole, Value: true, Usage: "Enable logging to stderr"},
+	}
+}
+
+// ConfigFromCLIContext turns the values parsed onto c by CLILogFlags into an
+// NLogConfig.
+func ConfigFromCLIContext(c *cli.Context) NLogConfig {
+	return NLogConfig{
+		OutputConsole: c.Bool(FlagLogConsole),
+		OutputFile:    c.Bool(FlagLogRotate),
+		LogPath:       c.String(FlagLogDir),
+		LogFile:       c.String(FlagLogFile),
+		MaxSize:       c.Int(FlagLogMaxSize),
+		MaxBackups:    c.Int(FlagLogMaxBackups),
+		MaxAge:        c.Int(FlagLogMaxAge),
+		Compress:      c.Bool(FlagLogCompress),
+		Level:         c.String(FlagLogLevel),
+		JSON:          c.Bool(FlagLogJSON),
+		NoColor:       c.Bool(FlagLogNoColor),
+	}
+}
+
+// NLogFromCLIContext is the urfave/cli counterpart to NLogFromFlags.
+func NLogFromCLIContext(c *cli.Context) *NLog {
+	return NewLog(ConfigFromCLIContext(c))
+}
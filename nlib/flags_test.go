@@ -0,0 +1,56 @@
+package nlib
+
+import (
+	"flag"
+	"testing"
+)
+
+func TestRegisterLogFlagsConfigDefaults(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags := RegisterLogFlags(fs)
+	if err := fs.Parse(nil); err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	cfg := flags.Config()
+	if !cfg.OutputConsole {
+		t.Error("want OutputConsole true by default")
+	}
+	if !cfg.OutputFile {
+		t.Error("want OutputFile true by default")
+	}
+	if cfg.LogFile != "app.log" {
+		t.Errorf("want default LogFile \"app.log\", got %q", cfg.LogFile)
+	}
+	if cfg.Level != "info" {
+		t.Errorf("want default Level \"info\", got %q", cfg.Level)
+	}
+}
+
+func TestRegisterLogFlagsConfigParsesOverrides(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags := RegisterLogFlags(fs)
+	args := []string{
+		"--" + FlagLogLevel, "debug",
+		"--" + FlagLogJSON,
+		"--" + FlagLogNoColor,
+		"--" + FlagLogFile, "custom.log",
+	}
+	if err := fs.Parse(args); err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	cfg := flags.Config()
+	if cfg.Level != "debug" {
+		t.Errorf("want Level \"debug\", got %q", cfg.Level)
+	}
+	if !cfg.JSON {
+		t.Error("want JSON true after --log.json")
+	}
+	if !cfg.NoColor {
+		t.Error("want NoColor true after --log.no-color")
+	}
+	if cfg.LogFile != "custom.log" {
+		t.Errorf("want LogFile \"custom.log\", got %q", cfg.LogFile)
+	}
+}
@@ -0,0 +1,176 @@
+package nlib
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Default tuning used by HTTPWriter when its exported fields are left at
+// their zero value, e.g. when a caller builds one as a struct literal
+// instead of going through NewHTTPWriter.
+const (
+	defaultHTTPBatchSize  = 100
+	defaultHTTPFlushEvery = 5 * time.Second
+	defaultHTTPMaxRetries = 3
+)
+
+// HTTPWriter is a resilient io.Writer that batches JSON-encoded zerolog
+// lines and POSTs them to a user-provided log-shipping endpoint, retrying
+// failed deliveries with exponential backoff. Like the other network sinks
+// in this package, Write never blocks on the network and never returns an
+// error, so a slow or unreachable collector cannot stall local logging. The
+// zero value falls back to the same defaults as NewHTTPWriter, so a struct
+// literal works just as well as the constructor.
+type HTTPWriter struct {
+	URL         string        // Endpoint to POST batched events to
+	BearerToken string        // Sent as "Authorization: Bearer <token>" when non-empty
+	BatchSize   int           // Max events buffered before a flush. Defaults to 100
+	FlushEvery  time.Duration // Max time an event waits before a flush. Defaults to 5s
+	MaxRetries  int           // Retries per batch after the first failed attempt before it is dropped. Defaults to 3
+	Client      *http.Client  // HTTP client used to POST batches. Defaults to http.DefaultClient
+
+	mu    sync.Mutex
+	batch [][]byte
+	timer *time.Timer
+	wg    sync.WaitGroup // tracks in-flight flush goroutines so Close can wait for them
+}
+
+// NewHTTPWriter returns an HTTPWriter that ships events to url, authenticated
+// with bearerToken when non-empty, and starts its background flush timer.
+func NewHTTPWriter(url, bearerToken string) *HTTPWriter {
+	w := &HTTPWriter{
+		URL:         url,
+		BearerToken: bearerToken,
+		BatchSize:   defaultHTTPBatchSize,
+		FlushEvery:  defaultHTTPFlushEvery,
+		MaxRetries:  defaultHTTPMaxRetries,
+		Client:      http.DefaultClient,
+	}
+	w.ensureTimer()
+	return w
+}
+
+func (w *HTTPWriter) batchSize() int {
+	if w.BatchSize <= 0 {
+		return defaultHTTPBatchSize
+	}
+	return w.BatchSize
+}
+
+func (w *HTTPWriter) flushEvery() time.Duration {
+	if w.FlushEvery <= 0 {
+		return defaultHTTPFlushEvery
+	}
+	return w.FlushEvery
+}
+
+func (w *HTTPWriter) maxRetries() int {
+	if w.MaxRetries <= 0 {
+		return defaultHTTPMaxRetries
+	}
+	return w.MaxRetries
+}
+
+func (w *HTTPWriter) client() *http.Client {
+	if w.Client == nil {
+		return http.DefaultClient
+	}
+	return w.Client
+}
+
+// ensureTimer lazily starts the background flush timer under w.mu, so a
+// struct-literal HTTPWriter that skipped NewHTTPWriter still gets one before
+// anything tries to Reset or Stop it.
+func (w *HTTPWriter) ensureTimer() *time.Timer {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timer == nil {
+		w.timer = time.AfterFunc(w.flushEvery(), w.flushAsync)
+	}
+	return w.timer
+}
+
+// Write appends p, a single JSON-encoded event, to the current batch,
+// flushing immediately once BatchSize is reached.
+func (w *HTTPWriter) Write(p []byte) (int, error) {
+	line := make([]byte, len(p))
+	copy(line, p)
+
+	w.mu.Lock()
+	w.batch = append(w.batch, line)
+	full := len(w.batch) >= w.batchSize()
+	w.mu.Unlock()
+	w.ensureTimer()
+
+	if full {
+		w.flushAsync()
+	}
+	return len(p), nil
+}
+
+func (w *HTTPWriter) flushAsync() {
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		w.flush()
+	}()
+	w.ensureTimer().Reset(w.flushEvery())
+}
+
+func (w *HTTPWriter) flush() {
+	w.mu.Lock()
+	if len(w.batch) == 0 {
+		w.mu.Unlock()
+		return
+	}
+	batch := w.batch
+	w.batch = nil
+	w.mu.Unlock()
+
+	payload := append([]byte("["), bytes.Join(batch, []byte(","))...)
+	payload = append(payload, ']')
+
+	backoff := 200 * time.Millisecond
+	for attempt := 0; attempt <= w.maxRetries(); attempt++ {
+		if w.post(payload) {
+			return
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+func (w *HTTPWriter) post(payload []byte) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(payload))
+	if err != nil {
+		return false
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if w.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+w.BearerToken)
+	}
+
+	resp, err := w.client().Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode >= 200 && resp.StatusCode < 300
+}
+
+// Close stops the background flush timer, flushes any buffered events, and
+// waits for every flush goroutine started by flushAsync (batch-full or
+// periodic) to finish its POST and retries before returning, so a deferred
+// Close can't exit the process while a batch is still in flight.
+func (w *HTTPWriter) Close() error {
+	w.ensureTimer().Stop()
+	w.flush()
+	w.wg.Wait()
+	return nil
+}
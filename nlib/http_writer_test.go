@@ -0,0 +1,76 @@
+package nlib
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHTTPWriterZeroValueIsSafe(t *testing.T) {
+	var received int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&received, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	// Built as a struct literal, the way every other field-exported writer
+	// in this package (e.g. SyslogWriter) supports, rather than through
+	// NewHTTPWriter.
+	w := &HTTPWriter{URL: srv.URL}
+	defer w.Close()
+
+	if _, err := w.Write([]byte(`{"level":"info","message":"hi"}`)); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	if atomic.LoadInt32(&received) != 1 {
+		t.Fatalf("want the zero-value HTTPWriter to deliver its batch, got %d requests", received)
+	}
+}
+
+func TestHTTPWriterCloseWaitsForInFlightFlush(t *testing.T) {
+	block := make(chan struct{})
+	var received int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-block
+		atomic.AddInt32(&received, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	hw := NewHTTPWriter(srv.URL, "")
+	hw.BatchSize = 1
+
+	if _, err := hw.Write([]byte(`{"level":"info","message":"hi"}`)); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		_ = hw.Close()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("Close returned before the in-flight flush's POST completed")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(block)
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Close did not return after the in-flight flush completed")
+	}
+
+	if atomic.LoadInt32(&received) != 1 {
+		t.Fatalf("want exactly 1 delivered batch, got %d", received)
+	}
+}
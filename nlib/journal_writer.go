@@ -0,0 +1,108 @@
+//go:build linux
+
+package nlib
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/coreos/go-systemd/v22/journal"
+	"github.com/rs/zerolog"
+)
+
+// JournalWriter is a resilient io.Writer that forwards zerolog JSON lines to
+// the local systemd-journald, mapping the zerolog level field to a journald
+// priority and every other field except the timestamp to a JOURNAL
+// KEY=VALUE entry. It is only
+// built on linux, since journald itself is linux-only.
+type JournalWriter struct {
+	// Tag is sent as the SYSLOG_IDENTIFIER field. Empty leaves it unset.
+	Tag string
+}
+
+// NewJournalWriter returns a JournalWriter tagged with the given
+// SYSLOG_IDENTIFIER. JournalAvailable can be used beforehand to check
+// whether journald is reachable at all.
+func NewJournalWriter(tag string) *JournalWriter {
+	return &JournalWriter{Tag: tag}
+}
+
+// JournalAvailable reports whether the systemd-journal socket can be reached,
+// mirroring journal.Enabled().
+func JournalAvailable() bool {
+	return journal.Enabled()
+}
+
+// Write decodes p as a single zerolog JSON line and forwards it to journald.
+// Malformed lines are sent verbatim at priority INFO rather than dropped, so
+// a formatting bug upstream never loses a log line.
+func (j *JournalWriter) Write(p []byte) (int, error) {
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(p, &fields); err != nil {
+		_ = journal.Send(string(p), journal.PriInfo, j.vars(nil))
+		return len(p), nil
+	}
+
+	priority := journalPriority(fields[zerolog.LevelFieldName])
+	msg, _ := fields[zerolog.MessageFieldName].(string)
+	delete(fields, zerolog.LevelFieldName)
+	delete(fields, zerolog.MessageFieldName)
+	delete(fields, zerolog.TimestampFieldName)
+
+	_ = journal.Send(msg, priority, j.vars(fields))
+	return len(p), nil
+}
+
+func (j *JournalWriter) vars(fields map[string]interface{}) map[string]string {
+	vars := make(map[string]string, len(fields)+1)
+	if j.Tag != "" {
+		vars["SYSLOG_IDENTIFIER"] = j.Tag
+	}
+	for k, v := range fields {
+		vars[journalKey(k)] = fmt.Sprintf("%v", v)
+	}
+	return vars
+}
+
+func journalPriority(level interface{}) journal.Priority {
+	l, _ := level.(string)
+	switch l {
+	case zerolog.LevelTraceValue, zerolog.LevelDebugValue:
+		return journal.PriDebug
+	case zerolog.LevelInfoValue:
+		return journal.PriInfo
+	case zerolog.LevelWarnValue:
+		return journal.PriWarning
+	case zerolog.LevelErrorValue:
+		return journal.PriErr
+	case zerolog.LevelFatalValue:
+		return journal.PriCrit
+	case zerolog.LevelPanicValue:
+		return journal.PriEmerg
+	default:
+		return journal.PriInfo
+	}
+}
+
+// journalKey upper-cases and sanitizes a zerolog field name into a valid
+// journald field name (letters, digits, and underscore, not starting with
+// an underscore or digit).
+func journalKey(k string) string {
+	out := make([]byte, 0, len(k)+1)
+	for i := 0; i < len(k); i++ {
+		c := k[i]
+		switch {
+		case c >= 'a' && c <= 'z':
+			c -= 'a' - 'A'
+			out = append(out, c)
+		case c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+			out = append(out, c)
+		default:
+			out = append(out, '_')
+		}
+	}
+	if len(out) == 0 || out[0] < 'A' || out[0] > 'Z' {
+		out = append([]byte{'F'}, out...)
+	}
+	return string(out)
+}
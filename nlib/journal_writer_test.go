@@ -0,0 +1,46 @@
+//go:build linux
+
+package nlib
+
+import (
+	"testing"
+
+	"github.com/coreos/go-systemd/v22/journal"
+	"github.com/rs/zerolog"
+)
+
+func TestJournalPriorityMapsZerologLevels(t *testing.T) {
+	cases := []struct {
+		level string
+		want  journal.Priority
+	}{
+		{zerolog.LevelTraceValue, journal.PriDebug},
+		{zerolog.LevelDebugValue, journal.PriDebug},
+		{zerolog.LevelInfoValue, journal.PriInfo},
+		{zerolog.LevelWarnValue, journal.PriWarning},
+		{zerolog.LevelErrorValue, journal.PriErr},
+		{zerolog.LevelFatalValue, journal.PriCrit},
+		{zerolog.LevelPanicValue, journal.PriEmerg},
+		{"unknown", journal.PriInfo},
+	}
+	for _, c := range cases {
+		if got := journalPriority(c.level); got != c.want {
+			t.Errorf("journalPriority(%q) = %v, want %v", c.level, got, c.want)
+		}
+	}
+}
+
+func TestJournalKeySanitizesFieldNames(t *testing.T) {
+	cases := map[string]string{
+		"level":     "LEVEL",
+		"user.name": "USER_NAME",
+		"Tag":       "TAG",
+		"123start":  "F123START",
+		"":          "F",
+	}
+	for in, want := range cases {
+		if got := journalKey(in); got != want {
+			t.Errorf("journalKey(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -21,34 +21,63 @@ type NLogConfig struct {
 	MaxSize       int    // MaxSize the max size in MB of the logfile before it's rolled
 	MaxBackups    int    // MaxBackups the max number of rolled files to keep
 	MaxAge        int    // MaxAge the max age in days to keep a logfile
+	Compress      bool   // Compress gzips rolled-over log files using lumberjack's built-in compression
+	LocalTime     bool   // LocalTime uses the host's local time instead of UTC for rolled file timestamps
+	Level         string // Level is the minimum level to log, parsed via zerolog.ParseLevel (trace..panic). Defaults to "info"
+	JSON          bool   // JSON makes the file writer emit raw zerolog JSON instead of going through ConsoleWriter
+	NoColor       bool   // NoColor disables ANSI colors on the console writer regardless of the NO_COLOR env var
+
+	Async         bool          // Async collates writes in a background goroutine instead of writing inline
+	BufferSize    int           // BufferSize is the max bytes buffered by the async worker before it flushes. Defaults to 256KiB
+	FlushInterval time.Duration // FlushInterval is the max time buffered entries wait before the async worker flushes. Defaults to 1s
+
+	Writers []io.Writer // Writers are additional sinks (e.g. SyslogWriter, JournalWriter, HTTPWriter) fanned out to alongside console and file logging
 }
 
 type NLog struct {
 	*zerolog.Logger
+	writer *resilientMultiWriter
+	async  *asyncWriter
 }
 
 func NewLog(config NLogConfig) *NLog {
+	noColor := config.NoColor || os.Getenv("NO_COLOR") != ""
 	var writers []io.Writer
 	if config.OutputFile {
-		writer := zerolog.ConsoleWriter{
-			Out: newRollingFile(config),
+		rollingFile := newRollingFile(config)
+		if config.JSON {
+			writers = append(writers, rollingFile)
+		} else {
+			writer := zerolog.ConsoleWriter{
+				Out:     rollingFile,
+				NoColor: noColor,
+			}
+			writer.FormatTimestamp = defaultTimestamp()
+			writer.FormatCaller = defaultCaller(noColor)
+			writer.FormatLevel = defaultFormatLevel(noColor)
+			writers = append(writers, writer)
 		}
-		writer.FormatTimestamp = defaultTimestamp()
-		writer.FormatCaller = defaultCaller(false)
-		writer.FormatLevel = defaultFormatLevel(false)
-		writers = append(writers, writer)
 	}
 	if config.OutputConsole {
 		writer := zerolog.ConsoleWriter{
 			Out: os.Stderr,
 		}
+		writer.NoColor = noColor
 		writer.FormatTimestamp = defaultTimestamp()
-		writer.FormatCaller = defaultCaller(false)
-		writer.FormatLevel = defaultFormatLevel(false)
+		writer.FormatCaller = defaultCaller(noColor)
+		writer.FormatLevel = defaultFormatLevel(noColor)
 		writers = append(writers, writer)
 	}
-	multiWriter := io.MultiWriter(writers...)
-	logger := zerolog.New(multiWriter).With().Timestamp().Caller().Logger()
+	writers = append(writers, config.Writers...)
+	multiWriter := newResilientMultiWriter(writers...)
+	var async *asyncWriter
+	var out io.Writer = multiWriter
+	if config.Async {
+		async = newAsyncWriter(multiWriter, config.BufferSize, config.FlushInterval)
+		out = async
+	}
+	level := parseLevel(config.Level)
+	logger := zerolog.New(out).Level(level).With().Timestamp().Caller().Logger()
 	logger.Info().Bool("fileLogging", config.OutputFile).
 		Bool("consoleLogging", config.OutputConsole).
 		Str("logPath", config.LogPath).
@@ -56,12 +85,61 @@ func NewLog(config NLogConfig) *NLog {
 		Int("maxSizeMB", config.MaxSize).
 		Int("maxBackup", config.MaxBackups).
 		Int("maxAgeInDays", config.MaxAge).
+		Str("level", level.String()).
+		Bool("async", config.Async).
 		Msg("logging configured")
 	return &NLog{
 		Logger: &logger,
+		writer: multiWriter,
+		async:  async,
+	}
+}
+
+// Flush blocks until all entries buffered by the async worker have been
+// written. It is a no-op when n was not constructed with NLogConfig.Async.
+func (n *NLog) Flush() {
+	if n.async != nil {
+		n.async.Flush()
 	}
 }
 
+// Close stops the async worker, if any, draining any buffered entries before
+// returning so no log line is lost on shutdown.
+func (n *NLog) Close() error {
+	if n.async != nil {
+		return n.async.Close()
+	}
+	return nil
+}
+
+// parseLevel resolves config.Level via zerolog.ParseLevel, falling back to
+// zerolog.InfoLevel when it is empty or unrecognized.
+func parseLevel(level string) zerolog.Level {
+	if level == "" {
+		return zerolog.InfoLevel
+	}
+	parsed, err := zerolog.ParseLevel(level)
+	if err != nil {
+		log.Warn().Err(err).Str("level", level).Msg("unknown log level, defaulting to info")
+		return zerolog.InfoLevel
+	}
+	return parsed
+}
+
+// SetLevel adjusts the minimum level logged by n at runtime.
+func (n *NLog) SetLevel(level zerolog.Level) {
+	*n.Logger = n.Logger.Level(level)
+}
+
+// WithMinLevel returns a copy of n whose minimum level is level, leaving n
+// itself untouched. Named to avoid shadowing the embedded *zerolog.Logger's
+// own WithLevel(level) *zerolog.Event, the idiom for logging a single event
+// at a dynamically-chosen level (n.WithLevel(lvl).Msg(...)).
+func (n *NLog) WithMinLevel(level zerolog.Level) *NLog {
+	logger := n.Logger.Level(level)
+	return &NLog{Logger: &logger, writer: n.writer, async: n.async}
+}
+
 func newRollingFile(config NLogConfig) io.Writer {
 	if err := os.MkdirAll(config.LogPath, 0744); err != nil {
 		log.Error().Err(err).Str("path", config.LogPath).Msg("can't create log directory")
@@ -72,6 +150,8 @@ func newRollingFile(config NLogConfig) io.Writer {
 		MaxBackups: config.MaxBackups,
 		MaxSize:    config.MaxSize,
 		MaxAge:     config.MaxAge,
+		Compress:   config.Compress,
+		LocalTime:  config.LocalTime,
 	}
 	return writer
 }
@@ -0,0 +1,67 @@
+package nlib
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestParseLevelDefaultsToInfo(t *testing.T) {
+	if got := parseLevel(""); got != zerolog.InfoLevel {
+		t.Fatalf("want InfoLevel for empty string, got %v", got)
+	}
+	if got := parseLevel("not-a-level"); got != zerolog.InfoLevel {
+		t.Fatalf("want InfoLevel fallback for unknown level, got %v", got)
+	}
+	if got := parseLevel("debug"); got != zerolog.DebugLevel {
+		t.Fatalf("want DebugLevel for \"debug\", got %v", got)
+	}
+}
+
+func TestNewLogAttachesExtraWriters(t *testing.T) {
+	var buf bytes.Buffer
+	n := NewLog(NLogConfig{Writers: []io.Writer{&buf}})
+
+	n.Info().Msg("hello from extra sink")
+
+	if !strings.Contains(buf.String(), "hello from extra sink") {
+		t.Fatalf("want config.Writers to receive log output, got %q", buf.String())
+	}
+}
+
+func TestNLogSetLevelFiltersBelowThreshold(t *testing.T) {
+	var buf bytes.Buffer
+	n := NewLog(NLogConfig{Writers: []io.Writer{&buf}})
+	buf.Reset()
+
+	n.SetLevel(zerolog.WarnLevel)
+	n.Info().Msg("should be filtered")
+	if strings.Contains(buf.String(), "should be filtered") {
+		t.Fatalf("want Info event suppressed after SetLevel(Warn), got %q", buf.String())
+	}
+
+	n.Warn().Msg("should pass")
+	if !strings.Contains(buf.String(), "should pass") {
+		t.Fatalf("want Warn event to pass after SetLevel(Warn), got %q", buf.String())
+	}
+}
+
+func TestNLogWithMinLevelLeavesOriginalUntouched(t *testing.T) {
+	var buf bytes.Buffer
+	n := NewLog(NLogConfig{Writers: []io.Writer{&buf}})
+	buf.Reset()
+
+	quiet := n.WithMinLevel(zerolog.ErrorLevel)
+	quiet.Warn().Msg("should be filtered on the copy")
+	if strings.Contains(buf.String(), "should be filtered on the copy") {
+		t.Fatalf("want WithMinLevel copy to filter Warn, got %q", buf.String())
+	}
+
+	n.Warn().Msg("should still pass on the original")
+	if !strings.Contains(buf.String(), "should still pass on the original") {
+		t.Fatalf("want original NLog untouched by WithMinLevel, got %q", buf.String())
+	}
+}
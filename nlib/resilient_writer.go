@@ -0,0 +1,72 @@
+package nlib
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/rs/zerolog/log"
+)
+
+// resilientMultiWriter fans writes out to every configured sink, the way
+// cloudflared's writer of the same name does: a single broken sink (a
+// detached console, a full disk under the file writer) must never stop the
+// others from receiving log lines, so per-writer errors are swallowed and
+// counted instead of being returned to the caller.
+type resilientMultiWriter struct {
+	writers []*countedWriter
+}
+
+type countedWriter struct {
+	w        io.Writer
+	failures uint64
+	warnOnce sync.Once
+}
+
+// newResilientMultiWriter builds a resilientMultiWriter over writers,
+// skipping any nil entries so callers can pass conditionally-built sinks
+// without filtering them first.
+func newResilientMultiWriter(writers ...io.Writer) *resilientMultiWriter {
+	rw := &resilientMultiWriter{}
+	for _, w := range writers {
+		if w == nil {
+			continue
+		}
+		rw.writers = append(rw.writers, &countedWriter{w: w})
+	}
+	return rw
+}
+
+// Write sends p to every sink. Per-writer errors are recorded and, after the
+// first occurrence, logged once through the fallback logger; Write itself
+// always reports success so one failing sink cannot stall the others.
+func (rw *resilientMultiWriter) Write(p []byte) (int, error) {
+	for _, cw := range rw.writers {
+		if _, err := cw.w.Write(p); err != nil {
+			atomic.AddUint64(&cw.failures, 1)
+			cw.warnOnce.Do(func() {
+				log.Warn().Err(err).Msg("log writer is failing, other sinks unaffected")
+			})
+		}
+	}
+	return len(p), nil
+}
+
+// WriterStats reports the number of failed writes observed per sink, in the
+// order the sinks were configured.
+func (rw *resilientMultiWriter) WriterStats() []uint64 {
+	stats := make([]uint64, len(rw.writers))
+	for i, cw := range rw.writers {
+		stats[i] = atomic.LoadUint64(&cw.failures)
+	}
+	return stats
+}
+
+// WriterStats returns the per-sink failed-write counts for n, in the order
+// the sinks were configured in NLogConfig (file writer before console writer).
+func (n *NLog) WriterStats() []uint64 {
+	if n.writer == nil {
+		return nil
+	}
+	return n.writer.WriterStats()
+}
@@ -0,0 +1,73 @@
+package nlib
+
+import (
+	"errors"
+	"testing"
+)
+
+type erroringWriter struct {
+	err   error
+	calls int
+}
+
+func (w *erroringWriter) Write(p []byte) (int, error) {
+	w.calls++
+	if w.err != nil {
+		return 0, w.err
+	}
+	return len(p), nil
+}
+
+func TestResilientMultiWriterSkipsNilWriters(t *testing.T) {
+	good := &erroringWriter{}
+	rw := newResilientMultiWriter(nil, good, nil)
+	if len(rw.writers) != 1 {
+		t.Fatalf("want 1 writer after skipping nils, got %d", len(rw.writers))
+	}
+	if _, err := rw.Write([]byte("hello")); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	if good.calls != 1 {
+		t.Fatalf("want 1 call to good writer, got %d", good.calls)
+	}
+}
+
+func TestResilientMultiWriterSurvivesFailingSink(t *testing.T) {
+	bad := &erroringWriter{err: errors.New("disk full")}
+	good := &erroringWriter{}
+	rw := newResilientMultiWriter(bad, good)
+
+	n, err := rw.Write([]byte("hello"))
+	if err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	if n != len("hello") {
+		t.Fatalf("want n=%d, got %d", len("hello"), n)
+	}
+	if good.calls != 1 {
+		t.Fatalf("want good writer still receiving writes, got %d calls", good.calls)
+	}
+}
+
+func TestResilientMultiWriterTracksFailureCounts(t *testing.T) {
+	bad := &erroringWriter{err: errors.New("connection reset")}
+	good := &erroringWriter{}
+	rw := newResilientMultiWriter(bad, good)
+
+	for i := 0; i < 3; i++ {
+		if _, err := rw.Write([]byte("x")); err != nil {
+			t.Fatalf("Write returned error: %v", err)
+		}
+	}
+
+	stats := rw.WriterStats()
+	if len(stats) != 2 {
+		t.Fatalf("want 2 stats entries, got %d", len(stats))
+	}
+	if stats[0] != 3 {
+		t.Errorf("want 3 failures recorded for bad writer, got %d", stats[0])
+	}
+	if stats[1] != 0 {
+		t.Errorf("want 0 failures recorded for good writer, got %d", stats[1])
+	}
+}
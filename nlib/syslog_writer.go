@@ -0,0 +1,178 @@
+package nlib
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Syslog facility codes, as defined by RFC5424 section 6.2.1.
+const (
+	FacilityKern = iota
+	FacilityUser
+	FacilityMail
+	FacilityDaemon
+	FacilityAuth
+	FacilitySyslog
+	FacilityLPR
+	FacilityNews
+	FacilityUUCP
+	FacilityCron
+	FacilityAuthPriv
+	FacilityFTP
+	_
+	_
+	_
+	_
+	FacilityLocal0
+	FacilityLocal1
+	FacilityLocal2
+	FacilityLocal3
+	FacilityLocal4
+	FacilityLocal5
+	FacilityLocal6
+	FacilityLocal7
+)
+
+// The severity* constants mirror the RFC5424 severities; zerolog levels are mapped
+// onto them by the syslogSeverity function below.
+const (
+	severityEmerg = iota
+	severityAlert
+	severityCrit
+	severityErr
+	severityWarning
+	severityNotice
+	severityInfo
+	severityDebug
+)
+
+// SyslogWriter is a resilient io.Writer that ships log lines to a remote
+// syslog daemon as RFC5424 messages. Like the other network sinks in this
+// package, it never returns an error from Write; connection problems are
+// retried lazily on the next write instead of propagating up and disrupting
+// the other sinks fanned out to by resilientMultiWriter.
+type SyslogWriter struct {
+	Network  string // "udp", "tcp", or "unix". NewSyslogWriter sets "udp" when given ""
+	Addr     string // host:port, or a socket path when Network is "unix"
+	Facility int    // One of the Facility* constants. The zero value is FacilityKern
+	Hostname string // Sent as the RFC5424 HOSTNAME field. NewSyslogWriter sets os.Hostname(); empty is sent as "-"
+	Tag      string // Sent as the RFC5424 APP-NAME field
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// NewSyslogWriter returns a SyslogWriter ready to dial network/addr lazily on
+// first write.
+func NewSyslogWriter(network, addr string, facility int, tag string) *SyslogWriter {
+	hostname, _ := os.Hostname()
+	if network == "" {
+		network = "udp"
+	}
+	return &SyslogWriter{
+		Network:  network,
+		Addr:     addr,
+		Facility: facility,
+		Hostname: hostname,
+		Tag:      tag,
+	}
+}
+
+func (s *SyslogWriter) connect() (net.Conn, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn != nil {
+		return s.conn, nil
+	}
+	conn, err := net.DialTimeout(s.Network, s.Addr, 5*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	s.conn = conn
+	return conn, nil
+}
+
+// Write formats p as the MSG of an RFC5424 packet, at the severity mapped
+// from p's zerolog level field, and sends it over s.conn, dialing lazily and
+// dropping the connection on error so the next write redials.
+func (s *SyslogWriter) Write(p []byte) (int, error) {
+	conn, err := s.connect()
+	if err != nil {
+		return len(p), nil
+	}
+	packet := formatRFC5424(s.Facility, syslogSeverity(p), s.Hostname, s.Tag, p)
+	if _, err := conn.Write(packet); err != nil {
+		s.mu.Lock()
+		if s.conn == conn {
+			_ = s.conn.Close()
+			s.conn = nil
+		}
+		s.mu.Unlock()
+	}
+	return len(p), nil
+}
+
+// Close closes the underlying connection, if any was established.
+func (s *SyslogWriter) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// syslogSeverity decodes p as a single zerolog JSON line and maps its level
+// field to an RFC5424 severity, the same way journalPriority maps it to a
+// journald priority. Malformed lines are sent at severityInfo rather than
+// dropped, so a formatting bug upstream never loses a log line.
+func syslogSeverity(p []byte) int {
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(p, &fields); err != nil {
+		return severityInfo
+	}
+	l, _ := fields[zerolog.LevelFieldName].(string)
+	switch l {
+	case zerolog.LevelTraceValue, zerolog.LevelDebugValue:
+		return severityDebug
+	case zerolog.LevelInfoValue:
+		return severityInfo
+	case zerolog.LevelWarnValue:
+		return severityWarning
+	case zerolog.LevelErrorValue:
+		return severityErr
+	case zerolog.LevelFatalValue:
+		return severityCrit
+	case zerolog.LevelPanicValue:
+		return severityEmerg
+	default:
+		return severityInfo
+	}
+}
+
+// formatRFC5424 builds an RFC5424 packet around msg. zerolog always appends
+// a trailing "\n" to every event (see event.go's AppendLineBreak), so msg is
+// trimmed of it first — otherwise the packet would carry an embedded
+// newline ahead of this function's own trailing "\n", corrupting framing
+// for newline-delimited TCP receivers.
+func formatRFC5424(facility, severity int, hostname, tag string, msg []byte) []byte {
+	priority := facility*8 + severity
+	timestamp := time.Now().Format(time.RFC3339)
+	if hostname == "" {
+		hostname = "-"
+	}
+	if tag == "" {
+		tag = "-"
+	}
+	msg = bytes.TrimRight(msg, "\n")
+	return []byte(fmt.Sprintf("<%d>1 %s %s %s - - - %s\n", priority, timestamp, hostname, tag, msg))
+}
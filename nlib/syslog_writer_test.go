@@ -0,0 +1,84 @@
+package nlib
+
+import (
+	"bytes"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSyslogSeverityMapsZerologLevels(t *testing.T) {
+	cases := []struct {
+		level string
+		want  int
+	}{
+		{`"debug"`, severityDebug},
+		{`"info"`, severityInfo},
+		{`"warn"`, severityWarning},
+		{`"error"`, severityErr},
+		{`"fatal"`, severityCrit},
+		{`"panic"`, severityEmerg},
+	}
+	for _, c := range cases {
+		line := []byte(`{"level":` + c.level + `,"message":"x"}`)
+		if got := syslogSeverity(line); got != c.want {
+			t.Errorf("syslogSeverity(level=%s) = %d, want %d", c.level, got, c.want)
+		}
+	}
+}
+
+func TestSyslogSeverityFallsBackToInfoOnMalformedJSON(t *testing.T) {
+	if got := syslogSeverity([]byte("not json")); got != severityInfo {
+		t.Fatalf("want severityInfo for malformed input, got %d", got)
+	}
+}
+
+func TestFormatRFC5424TrimsTrailingNewline(t *testing.T) {
+	packet := formatRFC5424(FacilityUser, severityInfo, "host", "tag", []byte("hello\n"))
+	if n := bytes.Count(packet, []byte("\n")); n != 1 {
+		t.Fatalf("want exactly one newline in the packet, got %d in %q", n, packet)
+	}
+	if !strings.HasSuffix(string(packet), "hello\n") {
+		t.Fatalf("want packet to end with the trimmed message, got %q", packet)
+	}
+}
+
+func TestSyslogWriterShipsOverTCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer ln.Close()
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		buf := make([]byte, 4096)
+		n, _ := conn.Read(buf)
+		received <- string(buf[:n])
+	}()
+
+	w := NewSyslogWriter("tcp", ln.Addr().String(), FacilityUser, "mytag")
+	defer w.Close()
+
+	if _, err := w.Write([]byte(`{"level":"error","message":"boom"}` + "\n")); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+
+	select {
+	case got := <-received:
+		if !strings.Contains(got, "mytag") || !strings.Contains(got, "boom") {
+			t.Fatalf("want packet to contain tag and message, got %q", got)
+		}
+		if strings.Count(got, "\n") != 1 {
+			t.Fatalf("want exactly one newline so the receiver sees one message, got %q", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for syslog packet")
+	}
+}